@@ -0,0 +1,9 @@
+package config
+
+// hash-max-listpack-entries and hash-max-listpack-value bound how large a hash can grow
+// before it is promoted from the compact listpack encoding to a full hashmap, mirroring
+// Redis's own hash-max-listpack-entries/hash-max-listpack-value directives.
+var (
+	HashMaxListpackEntries = 128
+	HashMaxListpackValue   = 64
+)
@@ -0,0 +1,4 @@
+package config
+
+// RDBFilename is the path SAVE/BGSAVE write their snapshot to and --loadrdb restores from.
+var RDBFilename = "dump.rdb"
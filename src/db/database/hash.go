@@ -4,6 +4,8 @@ import (
 	"github.com/shopspring/decimal"
 	"strconv"
 	"strings"
+	"time"
+	"traitor/db/config"
 	"traitor/db/interface/database"
 	"traitor/db/interface/redis"
 	"traitor/db/protocol"
@@ -30,7 +32,7 @@ func (db *DB) getOrInitDict(key string) (d dict.Dict, init bool, errReply protoc
 	}
 	init = false
 	if d == nil {
-		d = dict.MakeSimple()
+		d = dict.MakeListPack()
 		db.PutEntity(key, &database.DataEntity{
 			Data: d,
 		})
@@ -39,24 +41,104 @@ func (db *DB) getOrInitDict(key string) (d dict.Dict, init bool, errReply protoc
 	return d, init, nil
 }
 
+// maybeUpgradeDict converts key's listpack-encoded hash to a full hashmap once it grows past
+// hash-max-listpack-entries fields or any field/value exceeds hash-max-listpack-value bytes.
+// It is a no-op for hashes that are already using dict.SimpleDict.
+func (db *DB) maybeUpgradeDict(key string, d dict.Dict) {
+	lp, ok := d.(*dict.ListPack)
+	if !ok {
+		return
+	}
+	if lp.Len() <= config.HashMaxListpackEntries && lp.MaxEntrySize() <= config.HashMaxListpackValue {
+		return
+	}
+	simple := dict.MakeSimple()
+	lp.ForEach(func(field string, val interface{}) bool {
+		simple.Put(field, val)
+		if expireAtMs, hasTTL := lp.TTL(field); hasTTL {
+			simple.ExpireField(field, expireAtMs)
+		}
+		return true
+	})
+	db.PutEntity(key, &database.DataEntity{
+		Data: simple,
+	})
+}
+
+func nowMs() int64 {
+	return time.Now().UnixMilli()
+}
+
+// evictExpiredField removes field from d if its per-field TTL has passed, deleting key
+// entirely once the hash becomes empty as a result. Returns true if field was evicted.
+func (db *DB) evictExpiredField(key string, d dict.Dict, field string) bool {
+	expireAtMs, hasTTL := d.TTL(field)
+	if !hasTTL || expireAtMs > nowMs() {
+		return false
+	}
+	d.Remove(field)
+	if d.Len() == 0 {
+		db.Remove(key)
+	}
+	return true
+}
+
+// evictExpiredFields sweeps every field in d whose TTL has passed, deleting key entirely once
+// the hash becomes empty as a result. Returns the number of fields evicted.
+func (db *DB) evictExpiredFields(key string, d dict.Dict) int {
+	now := nowMs()
+	var expired []string
+	d.ForEach(func(field string, _ interface{}) bool {
+		if expireAtMs, hasTTL := d.TTL(field); hasTTL && expireAtMs <= now {
+			expired = append(expired, field)
+		}
+		return true
+	})
+	for _, field := range expired {
+		d.Remove(field)
+	}
+	if len(expired) > 0 && d.Len() == 0 {
+		db.Remove(key)
+	}
+	return len(expired)
+}
+
+// execHSet sets one or more field-value pairs in a hash table, returning the number of fields
+// that were newly created.
 func execHSet(db *DB, args [][]byte) redis.Reply {
+	if len(args)%2 != 1 {
+		return protocol.MakeSyntaxErrReply()
+	}
 	key := string(args[0])
-	field := string(args[1])
-	value := string(args[2])
+	size := (len(args) - 1) / 2
+	fields := make([]string, size)
+	values := make([][]byte, size)
+	for i := 0; i < size; i++ {
+		fields[i] = string(args[2*i+1])
+		values[i] = args[2*i+2]
+	}
 
 	d, _, errReply := db.getOrInitDict(key)
 	if errReply != nil {
 		return errReply
 	}
-	var res = d.Put(field, value)
+	created := 0
+	for i, field := range fields {
+		created += d.Put(field, values[i])
+	}
+	db.maybeUpgradeDict(key, d)
 	db.addAof(utils.ToCmdLine3("hset", args...))
-	return protocol.MakeIntReply(int64(res))
+	return protocol.MakeIntReply(int64(created))
 }
 
 func undoHSet(db *DB, args [][]byte) []CmdLine {
 	key := string(args[0])
-	field := string(args[1])
-	return rollbackHashFields(db, key, field)
+	size := (len(args) - 1) / 2
+	fields := make([]string, size)
+	for i := 0; i < size; i++ {
+		fields[i] = string(args[2*i+1])
+	}
+	return rollbackHashFields(db, key, fields...)
 }
 
 // execHSetNX sets field in hash table only if field not exists
@@ -73,6 +155,7 @@ func execHSetNX(db *DB, args [][]byte) redis.Reply {
 
 	result := d.PutIfAbsent(field, value)
 	if result > 0 {
+		db.maybeUpgradeDict(key, d)
 		db.addAof(utils.ToCmdLine3("hsetnx", args...))
 
 	}
@@ -93,6 +176,7 @@ func execHGet(db *DB, args [][]byte) redis.Reply {
 	if d == nil {
 		return &protocol.NullBulkReply{}
 	}
+	db.evictExpiredField(key, d, field)
 
 	raw, exists := d.Get(field)
 	if !exists {
@@ -116,6 +200,7 @@ func execHExists(db *DB, args [][]byte) redis.Reply {
 	if d == nil {
 		return protocol.MakeIntReply(0)
 	}
+	db.evictExpiredField(key, d, field)
 
 	_, exists := d.Get(field)
 	if exists {
@@ -180,6 +265,7 @@ func execHLen(db *DB, args [][]byte) redis.Reply {
 	if d == nil {
 		return protocol.MakeIntReply(0)
 	}
+	db.evictExpiredFields(key, d)
 	return protocol.MakeIntReply(int64(d.Len()))
 }
 
@@ -231,6 +317,7 @@ func execHMSet(db *DB, args [][]byte) redis.Reply {
 		value := values[i]
 		d.Put(field, value)
 	}
+	db.maybeUpgradeDict(key, d)
 	db.addAof(utils.ToCmdLine3("hmset", args...))
 	return protocol.MakeOkReply()
 }
@@ -262,6 +349,7 @@ func execHMGet(db *DB, args [][]byte) redis.Reply {
 	if d == nil {
 		return protocol.MakeMultiBulkReply(result)
 	}
+	db.evictExpiredFields(key, d)
 
 	for i, field := range fields {
 		value, ok := d.Get(field)
@@ -286,6 +374,7 @@ func execHKeys(db *DB, args [][]byte) redis.Reply {
 	if d == nil {
 		return &protocol.EmptyMultiBulkReply{}
 	}
+	db.evictExpiredFields(key, d)
 
 	fields := make([][]byte, d.Len())
 	i := 0
@@ -309,6 +398,7 @@ func execHVals(db *DB, args [][]byte) redis.Reply {
 	if d == nil {
 		return &protocol.EmptyMultiBulkReply{}
 	}
+	db.evictExpiredFields(key, d)
 
 	values := make([][]byte, d.Len())
 	i := 0
@@ -332,6 +422,7 @@ func execHGetAll(db *DB, args [][]byte) redis.Reply {
 	if d == nil {
 		return &protocol.EmptyMultiBulkReply{}
 	}
+	db.evictExpiredFields(key, d)
 
 	size := d.Len()
 	result := make([][]byte, size*2)
@@ -363,6 +454,7 @@ func execHIncrBy(db *DB, args [][]byte) redis.Reply {
 	value, exists := d.Get(field)
 	if !exists {
 		d.Put(field, args[2])
+		db.maybeUpgradeDict(key, d)
 		db.addAof(utils.ToCmdLine3("hincrby", args...))
 		return protocol.MakeBulkReply(args[2])
 	}
@@ -373,6 +465,7 @@ func execHIncrBy(db *DB, args [][]byte) redis.Reply {
 	val += delta
 	bytes := []byte(strconv.FormatInt(val, 10))
 	d.Put(field, bytes)
+	db.maybeUpgradeDict(key, d)
 	db.addAof(utils.ToCmdLine3("hincrby", args...))
 	return protocol.MakeBulkReply(bytes)
 }
@@ -402,6 +495,7 @@ func execHIncrByFloat(db *DB, args [][]byte) redis.Reply {
 	value, exists := d.Get(field)
 	if !exists {
 		d.Put(field, args[2])
+		db.maybeUpgradeDict(key, d)
 		return protocol.MakeBulkReply(args[2])
 	}
 	val, err := decimal.NewFromString(string(value.([]byte)))
@@ -411,6 +505,7 @@ func execHIncrByFloat(db *DB, args [][]byte) redis.Reply {
 	result := val.Add(delta)
 	resultBytes := []byte(result.String())
 	d.Put(field, resultBytes)
+	db.maybeUpgradeDict(key, d)
 	db.addAof(utils.ToCmdLine3("hincrbyfloat", args...))
 	return protocol.MakeBulkReply(resultBytes)
 }
@@ -448,6 +543,7 @@ func execHRandField(db *DB, args [][]byte) redis.Reply {
 	if d == nil {
 		return &protocol.EmptyMultiBulkReply{}
 	}
+	db.evictExpiredFields(key, d)
 
 	if count > 0 {
 		fields := d.RandomDistinctKeys(count)
@@ -491,8 +587,437 @@ func execHRandField(db *DB, args [][]byte) redis.Reply {
 	return &protocol.EmptyMultiBulkReply{}
 }
 
+// execHScan iterates over the fields of a hash using a cursor, supporting MATCH, COUNT and
+// NOVALUES options, mirroring the behaviour of SSCAN/ZSCAN.
+func execHScan(db *DB, args [][]byte) redis.Reply {
+	key := string(args[0])
+	cursor, err := strconv.Atoi(string(args[1]))
+	if err != nil {
+		return protocol.MakeErrReply("ERR invalid cursor")
+	}
+
+	pattern := "*"
+	count := 10
+	noValues := false
+	for i := 2; i < len(args); i++ {
+		switch strings.ToLower(string(args[i])) {
+		case "match":
+			if i+1 >= len(args) {
+				return protocol.MakeSyntaxErrReply()
+			}
+			pattern = string(args[i+1])
+			i++
+		case "count":
+			if i+1 >= len(args) {
+				return protocol.MakeSyntaxErrReply()
+			}
+			count64, err := strconv.ParseInt(string(args[i+1]), 10, 64)
+			if err != nil || count64 <= 0 {
+				return protocol.MakeErrReply("ERR value is not an integer or out of range")
+			}
+			count = int(count64)
+			i++
+		case "novalues":
+			noValues = true
+		default:
+			return protocol.MakeSyntaxErrReply()
+		}
+	}
+
+	d, errReply := db.getAsDict(key)
+	if errReply != nil {
+		return errReply
+	}
+	if d == nil {
+		return protocol.MakeMultiRawReply([]redis.Reply{
+			protocol.MakeBulkReply([]byte("0")),
+			&protocol.EmptyMultiBulkReply{},
+		})
+	}
+	db.evictExpiredFields(key, d)
+
+	fields, nextCursor := d.DictScan(cursor, count, pattern)
+	var result [][]byte
+	if noValues {
+		result = fields
+	} else {
+		result = make([][]byte, 0, len(fields)*2)
+		for _, field := range fields {
+			raw, exists := d.Get(string(field))
+			result = append(result, field)
+			if exists {
+				value, _ := raw.([]byte)
+				result = append(result, value)
+			} else {
+				result = append(result, nil)
+			}
+		}
+	}
+
+	return protocol.MakeMultiRawReply([]redis.Reply{
+		protocol.MakeBulkReply([]byte(strconv.Itoa(nextCursor))),
+		protocol.MakeMultiBulkReply(result),
+	})
+}
+
+// Per-field reply codes shared by the HEXPIRE/HTTL/HPERSIST command family, matching Redis 7.4.
+const (
+	hashFieldNoKeyOrField    = -2
+	hashFieldConditionNotMet = 0
+	hashFieldTTLSet          = 1
+	hashFieldDeleted         = 2
+)
+
+// parseHashFields parses the trailing `FIELDS numfields field [field ...]` clause shared by the
+// HEXPIRE/HTTL/HPERSIST command family.
+func parseHashFields(args [][]byte) (fields []string, err protocol.ErrorReply) {
+	if len(args) < 3 || strings.ToLower(string(args[0])) != "fields" {
+		return nil, protocol.MakeSyntaxErrReply()
+	}
+	numFields, convErr := strconv.Atoi(string(args[1]))
+	if convErr != nil || numFields <= 0 {
+		return nil, protocol.MakeErrReply("ERR numfields must be a positive integer")
+	}
+	if len(args)-2 != numFields {
+		return nil, protocol.MakeErrReply("ERR The `numfields` parameter must match the number of arguments")
+	}
+	fields = make([]string, numFields)
+	for i := 0; i < numFields; i++ {
+		fields[i] = string(args[2+i])
+	}
+	return fields, nil
+}
+
+// checkExpireCondition evaluates the NX/XX/GT/LT condition flags against a field's current TTL.
+// A field with no current expiration is treated as an infinite TTL for GT/LT, matching Redis.
+func checkExpireCondition(condition string, hasTTL bool, currentMs, newMs int64) bool {
+	switch condition {
+	case "NX":
+		return !hasTTL
+	case "XX":
+		return hasTTL
+	case "GT":
+		return hasTTL && newMs > currentMs
+	case "LT":
+		return !hasTTL || newMs < currentMs
+	default:
+		return true
+	}
+}
+
+// execHExpireGeneric implements HEXPIRE/HPEXPIRE/HEXPIREAT/HPEXPIREAT, which only differ in the
+// unit and absolute/relative interpretation of their time argument and the AOF command name.
+func execHExpireGeneric(db *DB, args [][]byte, cmdName string, toAbsMs func(int64) int64) redis.Reply {
+	key := string(args[0])
+	rawTime, convErr := strconv.ParseInt(string(args[1]), 10, 64)
+	if convErr != nil {
+		return protocol.MakeErrReply("ERR value is not an integer or out of range")
+	}
+
+	idx := 2
+	condition := ""
+	if idx < len(args) {
+		switch strings.ToUpper(string(args[idx])) {
+		case "NX", "XX", "GT", "LT":
+			condition = strings.ToUpper(string(args[idx]))
+			idx++
+		}
+	}
+
+	fields, errReply := parseHashFields(args[idx:])
+	if errReply != nil {
+		return errReply
+	}
+
+	d, errReply2 := db.getAsDict(key)
+	if errReply2 != nil {
+		return errReply2
+	}
+	if d == nil {
+		result := make([]redis.Reply, len(fields))
+		for i := range fields {
+			result[i] = protocol.MakeIntReply(hashFieldNoKeyOrField)
+		}
+		return protocol.MakeMultiRawReply(result)
+	}
+	db.evictExpiredFields(key, d)
+
+	absMs := toAbsMs(rawTime)
+	reply := make([]redis.Reply, len(fields))
+	changed := false
+	for i, field := range fields {
+		if _, exists := d.Get(field); !exists {
+			reply[i] = protocol.MakeIntReply(hashFieldNoKeyOrField)
+			continue
+		}
+		currentMs, hasTTL := d.TTL(field)
+		if !checkExpireCondition(condition, hasTTL, currentMs, absMs) {
+			reply[i] = protocol.MakeIntReply(hashFieldConditionNotMet)
+			continue
+		}
+		if absMs <= nowMs() {
+			d.Remove(field)
+			reply[i] = protocol.MakeIntReply(hashFieldDeleted)
+			changed = true
+			continue
+		}
+		d.ExpireField(field, absMs)
+		reply[i] = protocol.MakeIntReply(hashFieldTTLSet)
+		changed = true
+	}
+	if d.Len() == 0 {
+		db.Remove(key)
+	}
+	if changed {
+		db.addAof(utils.ToCmdLine3(cmdName, args...))
+	}
+	return protocol.MakeMultiRawReply(reply)
+}
+
+func execHExpire(db *DB, args [][]byte) redis.Reply {
+	return execHExpireGeneric(db, args, "hexpire", func(seconds int64) int64 {
+		return nowMs() + seconds*1000
+	})
+}
+
+func execHPExpire(db *DB, args [][]byte) redis.Reply {
+	return execHExpireGeneric(db, args, "hpexpire", func(ms int64) int64 {
+		return nowMs() + ms
+	})
+}
+
+func execHExpireAt(db *DB, args [][]byte) redis.Reply {
+	return execHExpireGeneric(db, args, "hexpireat", func(seconds int64) int64 {
+		return seconds * 1000
+	})
+}
+
+func execHPExpireAt(db *DB, args [][]byte) redis.Reply {
+	return execHExpireGeneric(db, args, "hpexpireat", func(ms int64) int64 {
+		return ms
+	})
+}
+
+// execHTTLGeneric implements HTTL/HPTTL/HEXPIRETIME/HPEXPIRETIME, which only differ in how the
+// field's unix-ms expiration time is formatted into the reply (remaining time or absolute time).
+func execHTTLGeneric(db *DB, args [][]byte, format func(expireAtMs int64) int64) redis.Reply {
+	key := string(args[0])
+	fields, errReply := parseHashFields(args[1:])
+	if errReply != nil {
+		return errReply
+	}
+
+	d, errReply2 := db.getAsDict(key)
+	if errReply2 != nil {
+		return errReply2
+	}
+	if d != nil {
+		db.evictExpiredFields(key, d)
+	}
+
+	reply := make([]redis.Reply, len(fields))
+	for i, field := range fields {
+		if d == nil {
+			reply[i] = protocol.MakeIntReply(hashFieldNoKeyOrField)
+			continue
+		}
+		if _, exists := d.Get(field); !exists {
+			reply[i] = protocol.MakeIntReply(hashFieldNoKeyOrField)
+			continue
+		}
+		expireAtMs, hasTTL := d.TTL(field)
+		if !hasTTL {
+			reply[i] = protocol.MakeIntReply(-1)
+			continue
+		}
+		reply[i] = protocol.MakeIntReply(format(expireAtMs))
+	}
+	return protocol.MakeMultiRawReply(reply)
+}
+
+func execHTTL(db *DB, args [][]byte) redis.Reply {
+	return execHTTLGeneric(db, args, func(expireAtMs int64) int64 {
+		return (expireAtMs - nowMs() + 999) / 1000
+	})
+}
+
+func execHPTTL(db *DB, args [][]byte) redis.Reply {
+	return execHTTLGeneric(db, args, func(expireAtMs int64) int64 {
+		return expireAtMs - nowMs()
+	})
+}
+
+func execHExpireTime(db *DB, args [][]byte) redis.Reply {
+	return execHTTLGeneric(db, args, func(expireAtMs int64) int64 {
+		return expireAtMs / 1000
+	})
+}
+
+func execHPExpireTime(db *DB, args [][]byte) redis.Reply {
+	return execHTTLGeneric(db, args, func(expireAtMs int64) int64 {
+		return expireAtMs
+	})
+}
+
+// execHPersist removes the TTL from the given fields, making them persist forever.
+func execHPersist(db *DB, args [][]byte) redis.Reply {
+	key := string(args[0])
+	fields, errReply := parseHashFields(args[1:])
+	if errReply != nil {
+		return errReply
+	}
+
+	d, errReply2 := db.getAsDict(key)
+	if errReply2 != nil {
+		return errReply2
+	}
+	if d != nil {
+		db.evictExpiredFields(key, d)
+	}
+
+	reply := make([]redis.Reply, len(fields))
+	changed := false
+	for i, field := range fields {
+		if d == nil {
+			reply[i] = protocol.MakeIntReply(hashFieldNoKeyOrField)
+			continue
+		}
+		if _, exists := d.Get(field); !exists {
+			reply[i] = protocol.MakeIntReply(hashFieldNoKeyOrField)
+			continue
+		}
+		if d.PersistField(field) {
+			reply[i] = protocol.MakeIntReply(1)
+			changed = true
+		} else {
+			reply[i] = protocol.MakeIntReply(-1)
+		}
+	}
+	if changed {
+		db.addAof(utils.ToCmdLine3("hpersist", args...))
+	}
+	return protocol.MakeMultiRawReply(reply)
+}
+
+// prepareHashMove marks both the source and destination keys as write keys so the cluster
+// router forwards HCOPY/HMOVE to a single node when the two keys hash to the same slot.
+func prepareHashMove(args [][]byte) ([]string, []string) {
+	return []string{string(args[0]), string(args[1])}, nil
+}
+
+// execHCopy copies field from srckey to dstkey, leaving srckey untouched. dstkey is created as
+// a hash if absent; an existing field in dstkey is only overwritten when REPLACE is given.
+// HCOPY srckey dstkey field [DB db] [REPLACE]. This server has no mechanism for reaching
+// another DB's keyspace from inside a command, so DB is only accepted when it names the
+// current database (a no-op, same as omitting it) and rejected otherwise.
+func execHCopy(db *DB, args [][]byte) redis.Reply {
+	srcKey := string(args[0])
+	dstKey := string(args[1])
+	field := string(args[2])
+
+	replace := false
+	for i := 3; i < len(args); i++ {
+		switch strings.ToUpper(string(args[i])) {
+		case "REPLACE":
+			replace = true
+		case "DB":
+			if i+1 >= len(args) {
+				return protocol.MakeSyntaxErrReply()
+			}
+			dbIndex, err := strconv.Atoi(string(args[i+1]))
+			if err != nil {
+				return protocol.MakeErrReply("ERR value is not an integer or out of range")
+			}
+			if dbIndex != db.index {
+				return protocol.MakeErrReply("ERR HCOPY across databases is not supported")
+			}
+			i++
+		default:
+			return protocol.MakeSyntaxErrReply()
+		}
+	}
+
+	srcDict, errReply := db.getAsDict(srcKey)
+	if errReply != nil {
+		return errReply
+	}
+	if srcDict == nil {
+		return protocol.MakeIntReply(0)
+	}
+	value, exists := srcDict.Get(field)
+	if !exists {
+		return protocol.MakeIntReply(0)
+	}
+
+	dstDict, _, errReply := db.getOrInitDict(dstKey)
+	if errReply != nil {
+		return errReply
+	}
+	if _, exists := dstDict.Get(field); exists && !replace {
+		return protocol.MakeIntReply(0)
+	}
+
+	dstDict.Put(field, value)
+	db.maybeUpgradeDict(dstKey, dstDict)
+	db.addAof(utils.ToCmdLine3("hcopy", args...))
+	return protocol.MakeIntReply(1)
+}
+
+func undoHCopy(db *DB, args [][]byte) []CmdLine {
+	dstKey := string(args[1])
+	field := string(args[2])
+	return rollbackHashFields(db, dstKey, field)
+}
+
+// execHMove atomically moves field from srckey to dstkey, initializing dstkey as a hash if
+// absent and deleting srckey entirely once it becomes empty, mirroring execHDel's cleanup.
+func execHMove(db *DB, args [][]byte) redis.Reply {
+	srcKey := string(args[0])
+	dstKey := string(args[1])
+	field := string(args[2])
+
+	if srcKey == dstKey {
+		return protocol.MakeIntReply(0)
+	}
+
+	srcDict, errReply := db.getAsDict(srcKey)
+	if errReply != nil {
+		return errReply
+	}
+	if srcDict == nil {
+		return protocol.MakeIntReply(0)
+	}
+	value, exists := srcDict.Get(field)
+	if !exists {
+		return protocol.MakeIntReply(0)
+	}
+
+	dstDict, _, errReply := db.getOrInitDict(dstKey)
+	if errReply != nil {
+		return errReply
+	}
+
+	srcDict.Remove(field)
+	if srcDict.Len() == 0 {
+		db.Remove(srcKey)
+	}
+	dstDict.Put(field, value)
+	db.maybeUpgradeDict(dstKey, dstDict)
+	db.addAof(utils.ToCmdLine3("hmove", args...))
+	return protocol.MakeIntReply(1)
+}
+
+func undoHMove(db *DB, args [][]byte) []CmdLine {
+	srcKey := string(args[0])
+	dstKey := string(args[1])
+	field := string(args[2])
+	cmds := rollbackHashFields(db, srcKey, field)
+	cmds = append(cmds, rollbackHashFields(db, dstKey, field)...)
+	return cmds
+}
+
 func init() {
-	RegisterCommand("HSet", execHSet, writeFirstKey, undoHSet, 4, flagWrite)
+	RegisterCommand("HSet", execHSet, writeFirstKey, undoHSet, -4, flagWrite)
 	RegisterCommand("HSetNX", execHSetNX, writeFirstKey, undoHSet, 4, flagWrite)
 	RegisterCommand("HGet", execHGet, readFirstKey, nil, 3, flagReadOnly)
 	RegisterCommand("HExists", execHExists, readFirstKey, nil, 3, flagReadOnly)
@@ -501,11 +1026,22 @@ func init() {
 	RegisterCommand("HStrlen", execHStrLen, readFirstKey, nil, 3, flagReadOnly)
 	RegisterCommand("HMSet", execHMSet, writeFirstKey, undoHMSet, -4, flagWrite)
 	RegisterCommand("HMGet", execHMGet, readFirstKey, nil, -3, flagReadOnly)
-	RegisterCommand("HGet", execHGet, readFirstKey, nil, -3, flagReadOnly)
 	RegisterCommand("HKeys", execHKeys, readFirstKey, nil, 2, flagReadOnly)
 	RegisterCommand("HVals", execHVals, readFirstKey, nil, 2, flagReadOnly)
 	RegisterCommand("HGetAll", execHGetAll, readFirstKey, nil, 2, flagReadOnly)
 	RegisterCommand("HIncrBy", execHIncrBy, writeFirstKey, undoHIncr, 4, flagWrite)
 	RegisterCommand("HIncrByFloat", execHIncrByFloat, writeFirstKey, undoHIncr, 4, flagWrite)
 	RegisterCommand("HRandField", execHRandField, readFirstKey, nil, -2, flagReadOnly)
+	RegisterCommand("HScan", execHScan, readFirstKey, nil, -3, flagReadOnly)
+	RegisterCommand("HExpire", execHExpire, writeFirstKey, nil, -6, flagWrite)
+	RegisterCommand("HPExpire", execHPExpire, writeFirstKey, nil, -6, flagWrite)
+	RegisterCommand("HExpireAt", execHExpireAt, writeFirstKey, nil, -6, flagWrite)
+	RegisterCommand("HPExpireAt", execHPExpireAt, writeFirstKey, nil, -6, flagWrite)
+	RegisterCommand("HTTL", execHTTL, readFirstKey, nil, -5, flagReadOnly)
+	RegisterCommand("HPTTL", execHPTTL, readFirstKey, nil, -5, flagReadOnly)
+	RegisterCommand("HExpireTime", execHExpireTime, readFirstKey, nil, -5, flagReadOnly)
+	RegisterCommand("HPExpireTime", execHPExpireTime, readFirstKey, nil, -5, flagReadOnly)
+	RegisterCommand("HPersist", execHPersist, writeFirstKey, nil, -5, flagWrite)
+	RegisterCommand("HCopy", execHCopy, prepareHashMove, undoHCopy, -4, flagWrite)
+	RegisterCommand("HMove", execHMove, prepareHashMove, undoHMove, 4, flagWrite)
 }
@@ -0,0 +1,41 @@
+package database
+
+import "testing"
+
+func TestCheckExpireCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition string
+		hasTTL    bool
+		currentMs int64
+		newMs     int64
+		want      bool
+	}{
+		{"NX on field with no TTL", "NX", false, 0, 1000, true},
+		{"NX on field with existing TTL", "NX", true, 500, 1000, false},
+
+		{"XX on field with no TTL", "XX", false, 0, 1000, false},
+		{"XX on field with existing TTL", "XX", true, 500, 1000, true},
+
+		{"GT on field with no TTL", "GT", false, 0, 1000, false},
+		{"GT new greater than current", "GT", true, 500, 1000, true},
+		{"GT new equal to current", "GT", true, 1000, 1000, false},
+		{"GT new less than current", "GT", true, 1000, 500, false},
+
+		{"LT on field with no TTL", "LT", false, 0, 1000, true},
+		{"LT new less than current", "LT", true, 1000, 500, true},
+		{"LT new equal to current", "LT", true, 1000, 1000, false},
+		{"LT new greater than current", "LT", true, 500, 1000, false},
+
+		{"no condition always matches", "", true, 500, 1000, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkExpireCondition(tt.condition, tt.hasTTL, tt.currentMs, tt.newMs)
+			if got != tt.want {
+				t.Errorf("checkExpireCondition(%q, %v, %d, %d) = %v, want %v",
+					tt.condition, tt.hasTTL, tt.currentMs, tt.newMs, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"traitor/db/config"
+	"traitor/db/interface/redis"
+	"traitor/db/persistence/rdb"
+	"traitor/db/protocol"
+)
+
+// noPrepare marks a command as touching no keys, so the cluster/transaction layer doesn't try
+// to lock or route it like a keyed command.
+func noPrepare(args [][]byte) ([]string, []string) {
+	return nil, nil
+}
+
+// execSave synchronously snapshots the dataset to config.RDBFilename in RDB format.
+func execSave(db *DB, args [][]byte) redis.Reply {
+	if err := rdb.DumpDB(db, config.RDBFilename); err != nil {
+		return protocol.MakeErrReply("ERR " + err.Error())
+	}
+	return protocol.MakeOkReply()
+}
+
+// execBGSave snapshots the dataset to config.RDBFilename. dict.Dict implementations are not
+// thread safe, so unlike real Redis (which forks to get a copy-on-write snapshot) this runs
+// the dump on the command goroutine instead of in the background, to avoid reading the live
+// dicts concurrently with the writes the dispatch loop keeps applying to them.
+func execBGSave(db *DB, args [][]byte) redis.Reply {
+	if err := rdb.DumpDB(db, config.RDBFilename); err != nil {
+		return protocol.MakeErrReply("ERR " + err.Error())
+	}
+	return protocol.MakeOkReply()
+}
+
+// LoadRDB loads the RDB snapshot at path into db, for startup when --loadrdb is set. It does
+// not rewrite the AOF file; the caller rewrites it from the resulting in-memory state afterward
+// so the two stores agree going forward.
+func LoadRDB(db *DB, path string) error {
+	return rdb.LoadDB(db, path)
+}
+
+func init() {
+	RegisterCommand("Save", execSave, noPrepare, nil, 1, flagReadOnly)
+	RegisterCommand("BGSave", execBGSave, noPrepare, nil, 1, flagReadOnly)
+}
@@ -0,0 +1,136 @@
+// Package wildcard implements glob-style pattern matching for KEYS/SCAN MATCH patterns:
+// '*', '?', '[...]' and '[^...]' character classes, and '\' to escape the next character
+// (inside a class as well). A class holds single bytes only: ranges such as [a-z] are not
+// supported ('-' matches itself), and '*', '?' or '[' inside a class make CompilePattern fail.
+package wildcard
+
+import "errors"
+
+const (
+	normal = iota
+	all
+	any
+	closure
+)
+
+type item struct {
+	character byte
+	set       map[byte]bool
+	negate    bool
+	typeCode  int
+}
+
+// Pattern is a compiled glob pattern
+type Pattern struct {
+	items []*item
+}
+
+// CompilePattern compiles a glob pattern string into a Pattern
+func CompilePattern(src string) (*Pattern, error) {
+	items := make([]*item, 0, len(src))
+	escape := false
+	inSet := false
+	var set map[byte]bool
+	var negate bool
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		if escape {
+			if inSet {
+				set[c] = true
+			} else {
+				items = append(items, &item{character: c, typeCode: normal})
+			}
+			escape = false
+			continue
+		}
+		switch c {
+		case '*':
+			if inSet {
+				return nil, errors.New("ERR unterminated character class in pattern")
+			}
+			items = append(items, &item{typeCode: all})
+		case '?':
+			if inSet {
+				return nil, errors.New("ERR unterminated character class in pattern")
+			}
+			items = append(items, &item{typeCode: any})
+		case '\\':
+			escape = true
+		case '[':
+			if inSet {
+				return nil, errors.New("ERR unterminated character class in pattern")
+			}
+			inSet = true
+			set = make(map[byte]bool)
+			negate = false
+			if i+1 < len(src) && src[i+1] == '^' {
+				negate = true
+				i++
+			}
+		case ']':
+			if !inSet {
+				items = append(items, &item{character: c, typeCode: normal})
+				continue
+			}
+			inSet = false
+			items = append(items, &item{set: set, negate: negate, typeCode: closure})
+		default:
+			if inSet {
+				set[c] = true
+			} else {
+				items = append(items, &item{character: c, typeCode: normal})
+			}
+		}
+	}
+	if inSet {
+		return nil, errors.New("ERR unterminated character class in pattern")
+	}
+	return &Pattern{items: items}, nil
+}
+
+// IsMatch reports whether s matches the compiled pattern
+func (p *Pattern) IsMatch(s string) bool {
+	return isMatch(p.items, []byte(s))
+}
+
+// itemMatches reports whether a single non-'*' item matches byte c.
+func itemMatches(it *item, c byte) bool {
+	switch it.typeCode {
+	case any:
+		return true
+	case closure:
+		return it.set[c] != it.negate
+	default:
+		return it.character == c
+	}
+}
+
+// isMatch walks items against s with two pointers instead of recursing into every possible
+// split at each '*', which blows up exponentially on patterns with several stars (e.g.
+// "*a*a*a...b" against a long run of "a"s). Whenever a '*' is hit, its position is remembered;
+// on a later mismatch we backtrack to just after that '*' and let it consume one more
+// character, which is the standard linear "wildmatch"/fnmatch approach.
+func isMatch(items []*item, s []byte) bool {
+	i, j := 0, 0
+	starIdx, matchIdx := -1, -1
+	for j < len(s) {
+		if i < len(items) && items[i].typeCode == all {
+			starIdx = i
+			matchIdx = j
+			i++
+		} else if i < len(items) && itemMatches(items[i], s[j]) {
+			i++
+			j++
+		} else if starIdx != -1 {
+			i = starIdx + 1
+			matchIdx++
+			j = matchIdx
+		} else {
+			return false
+		}
+	}
+	for i < len(items) && items[i].typeCode == all {
+		i++
+	}
+	return i == len(items)
+}
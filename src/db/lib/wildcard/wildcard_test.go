@@ -0,0 +1,99 @@
+package wildcard
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustCompile(t *testing.T, pattern string) *Pattern {
+	t.Helper()
+	p, err := CompilePattern(pattern)
+	if err != nil {
+		t.Fatalf("CompilePattern(%q) error = %v", pattern, err)
+	}
+	return p
+}
+
+func TestIsMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		s       string
+		want    bool
+	}{
+		// literal
+		{"hello", "hello", true},
+		{"hello", "hellox", false},
+
+		// '*'
+		{"*", "anything", true},
+		{"*", "", true},
+		{"h*o", "hello", true},
+		{"h*o", "ho", true},
+		{"h*o", "hx", false},
+		{"*llo", "hello", true},
+		{"he*", "hello", true},
+		{"*a*a*a*", "banana", true},
+		{"*a*a*a*", "ban", false},
+
+		// '?'
+		{"h?llo", "hello", true},
+		{"h?llo", "hllo", false},
+		{"????", "abcd", true},
+		{"????", "abc", false},
+
+		// '[...]' and '[^...]'
+		{"h[ae]llo", "hello", true},
+		{"h[ae]llo", "hallo", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^ae]llo", "hillo", true},
+		{"h[^ae]llo", "hello", false},
+
+		// escapes
+		{`\*`, "*", true},
+		{`\*`, "x", false},
+		{`\?`, "?", true},
+		{`a\[b`, "a[b", true},
+
+		// unmatched ']' outside a class is literal
+		{"a]b", "a]b", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.s, func(t *testing.T) {
+			p := mustCompile(t, tt.pattern)
+			if got := p.IsMatch(tt.s); got != tt.want {
+				t.Errorf("IsMatch(%q) against pattern %q = %v, want %v", tt.s, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsMatchUnterminatedClass(t *testing.T) {
+	if _, err := CompilePattern("h[ae"); err == nil {
+		t.Fatalf("CompilePattern(\"h[ae\") error = nil, want unterminated character class error")
+	}
+}
+
+// TestIsMatchManyStarsIsLinear exercises the pathological pattern the linear-wildmatch fix
+// targets: many '*a' pairs against a run of 'a's, ending in a 'b' that doesn't appear in the
+// subject, forcing the old recursive matcher into exponential backtracking. If isMatch regressed to recursing
+// over every possible '*' split, this test would hang instead of returning quickly.
+func TestIsMatchManyStarsIsLinear(t *testing.T) {
+	pattern := strings.Repeat("*a", 20) + "b"
+	s := strings.Repeat("a", 30)
+	p := mustCompile(t, pattern)
+
+	done := make(chan bool, 1)
+	go func() {
+		done <- p.IsMatch(s)
+	}()
+
+	select {
+	case got := <-done:
+		if got {
+			t.Fatalf("IsMatch(%q) against pattern %q = true, want false (subject has no trailing 'b')", s, pattern)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("IsMatch did not return quickly; looks like exponential backtracking regressed")
+	}
+}
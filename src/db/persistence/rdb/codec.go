@@ -0,0 +1,189 @@
+// Package rdb implements a Redis-compatible RDB snapshot encoder/decoder, used by SAVE/BGSAVE
+// and --loadrdb to persist and restore the in-memory dataset independently of the AOF log.
+package rdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"strconv"
+)
+
+const magicHeader = "REDIS0011"
+
+// RDB opcodes, matching stock Redis's RDB format.
+const (
+	opExpireMs = 0xFC
+	opSelectDB = 0xFE
+	opEOF      = 0xFF
+)
+
+// Value-type tags understood by this codec, aligned with Redis's RDB_TYPE_* constants.
+const (
+	TypeString       = 0
+	TypeHash         = 4
+	TypeHashListpack = 16
+)
+
+// TypeHashMetadata and TypeHashListpackEx are the trailer-bearing variants of TypeHash and
+// TypeHashListpack, used only when a hash has at least one per-field TTL (see encodeFieldTTLs).
+// A plain TypeHash/TypeHashListpack record never carries that trailer. A TypeHash record is
+// byte-for-byte what a stock Redis-compatible RDB reader expects; a TypeHashListpack record holds
+// dict.ListPack's own entry buffer (see EncodeHashListpack), not Redis's listpack layout, so a
+// stock reader cannot parse it. Real Redis 7.4+ also has per-field hash
+// TTLs, under the *same names* RDB_TYPE_HASH_METADATA (24) and RDB_TYPE_HASH_LISTPACK_EX (25) —
+// but its wire format for them (relative TTL deltas off a min-expire header) doesn't match this
+// codec's trailer (field + 8-byte absolute-ms pairs, see encodeFieldTTLs). Reusing 24/25 would
+// make a TTL-bearing hash from one codec silently misparse under the other instead of being
+// rejected, so these use tag values outside Redis's real RDB_TYPE_* range instead.
+const (
+	TypeHashMetadata   = 200
+	TypeHashListpackEx = 201
+)
+
+// ErrUnsupportedType is returned for a DataEntity whose value this codec cannot (yet) encode
+// or decode.
+var ErrUnsupportedType = errors.New("rdb: unsupported value type")
+
+// ErrBadMagic is returned when a file does not start with the expected RDB header.
+var ErrBadMagic = errors.New("rdb: bad magic header")
+
+// ErrCompressedString is returned for an RDB_ENC_LZF-encoded string, since this codec has no
+// LZF decompressor; misreading one as a plain length would silently corrupt everything after it.
+var ErrCompressedString = errors.New("rdb: LZF-compressed strings are not supported")
+
+// Special string-encoding subtypes, used under the 11-prefix length byte (see
+// readLengthOrEncoding) in place of a plain length-prefixed string.
+const (
+	encInt8  = 0
+	encInt16 = 1
+	encInt32 = 2
+	encLZF   = 3
+)
+
+func writeByte(w io.Writer, b byte) error {
+	_, err := w.Write([]byte{b})
+	return err
+}
+
+// writeLength encodes n using the standard RDB 6/14/32-bit length scheme.
+func writeLength(w io.Writer, n int) error {
+	switch {
+	case n < 1<<6:
+		return writeByte(w, byte(n))
+	case n < 1<<14:
+		_, err := w.Write([]byte{0x40 | byte(n>>8), byte(n)})
+		return err
+	default:
+		if err := writeByte(w, 0x80); err != nil {
+			return err
+		}
+		return binary.Write(w, binary.BigEndian, uint32(n))
+	}
+}
+
+// readLengthOrEncoding decodes one RDB length header. The top two bits of the first byte select
+// the shape: 00/01 are 6-bit/14-bit plain lengths, 10 is a 32-bit plain length, and 11 means what
+// follows isn't a length at all but a "special encoding" (a packed integer or an LZF-compressed
+// string) whose subtype is the remaining 6 bits — returned via isEncoded/encType instead of n.
+func readLengthOrEncoding(r io.Reader) (n int, isEncoded bool, encType byte, err error) {
+	head := make([]byte, 1)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return 0, false, 0, err
+	}
+	switch head[0] >> 6 {
+	case 0:
+		return int(head[0] & 0x3f), false, 0, nil
+	case 1:
+		next := make([]byte, 1)
+		if _, err := io.ReadFull(r, next); err != nil {
+			return 0, false, 0, err
+		}
+		return int(head[0]&0x3f)<<8 | int(next[0]), false, 0, nil
+	case 2:
+		var v uint32
+		if err := binary.Read(r, binary.BigEndian, &v); err != nil {
+			return 0, false, 0, err
+		}
+		return int(v), false, 0, nil
+	default:
+		return 0, true, head[0] & 0x3f, nil
+	}
+}
+
+// readLength decodes a plain RDB length; a special encoding (case 3 above) is never valid in a
+// plain-length context (e.g. a field count), so it's reported as an error rather than silently
+// read as if it were a 32-bit length.
+func readLength(r io.Reader) (int, error) {
+	n, isEncoded, _, err := readLengthOrEncoding(r)
+	if err != nil {
+		return 0, err
+	}
+	if isEncoded {
+		return 0, errors.New("rdb: unexpected special-encoded length")
+	}
+	return n, nil
+}
+
+// readEncodedString decodes the payload of a special string encoding (see
+// readLengthOrEncoding): a packed little-endian integer, formatted back as its decimal string,
+// or an error for the one subtype (LZF) this codec can't decompress.
+func readEncodedString(r io.Reader, encType byte) ([]byte, error) {
+	switch encType {
+	case encInt8:
+		var v int8
+		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+			return nil, err
+		}
+		return []byte(strconv.Itoa(int(v))), nil
+	case encInt16:
+		var v int16
+		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+			return nil, err
+		}
+		return []byte(strconv.Itoa(int(v))), nil
+	case encInt32:
+		var v int32
+		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+			return nil, err
+		}
+		return []byte(strconv.Itoa(int(v))), nil
+	case encLZF:
+		return nil, ErrCompressedString
+	default:
+		return nil, errors.New("rdb: unknown special string encoding")
+	}
+}
+
+func writeBytes(w io.Writer, b []byte) error {
+	if err := writeLength(w, len(b)); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func readBytes(r io.Reader) ([]byte, error) {
+	n, isEncoded, encType, err := readLengthOrEncoding(r)
+	if err != nil {
+		return nil, err
+	}
+	if isEncoded {
+		return readEncodedString(r, encType)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// EncodeString serializes a string/bulk value as RDB_TYPE_STRING.
+func EncodeString(w io.Writer, value []byte) error {
+	return writeBytes(w, value)
+}
+
+// DecodeString reads back a value written by EncodeString.
+func DecodeString(r io.Reader) ([]byte, error) {
+	return readBytes(r)
+}
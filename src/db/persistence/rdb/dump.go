@@ -0,0 +1,193 @@
+package rdb
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"traitor/db/interface/database"
+	"traitor/db/struct/dict"
+)
+
+// DBIterator is the subset of database.DB used to walk every key during a dump.
+type DBIterator interface {
+	ForEach(consumer func(key string, entity *database.DataEntity, expiration *time.Time) bool)
+}
+
+// DBLoader is the subset of database.DB used to repopulate entities during a load.
+type DBLoader interface {
+	PutEntity(key string, entity *database.DataEntity)
+}
+
+func encodeEntity(w io.Writer, key string, data interface{}) error {
+	var typeByte byte
+	switch v := data.(type) {
+	case []byte:
+		typeByte = TypeString
+	case *dict.ListPack:
+		if HasFieldTTL(v) {
+			typeByte = TypeHashListpackEx
+		} else {
+			typeByte = TypeHashListpack
+		}
+	case dict.Dict:
+		if HasFieldTTL(v) {
+			typeByte = TypeHashMetadata
+		} else {
+			typeByte = TypeHash
+		}
+	default:
+		return ErrUnsupportedType
+	}
+	if err := writeByte(w, typeByte); err != nil {
+		return err
+	}
+	if err := writeBytes(w, []byte(key)); err != nil {
+		return err
+	}
+	switch typeByte {
+	case TypeString:
+		return EncodeString(w, data.([]byte))
+	case TypeHashListpack:
+		return EncodeHashListpack(w, data.(*dict.ListPack))
+	case TypeHashListpackEx:
+		return EncodeHashListpackWithTTL(w, data.(*dict.ListPack))
+	case TypeHashMetadata:
+		return EncodeHashWithTTL(w, data.(dict.Dict))
+	default:
+		return EncodeHash(w, data.(dict.Dict))
+	}
+}
+
+func decodeValue(r io.Reader, typeByte byte) (interface{}, error) {
+	switch typeByte {
+	case TypeString:
+		return DecodeString(r)
+	case TypeHash:
+		return DecodeHash(r)
+	case TypeHashMetadata:
+		return DecodeHashWithTTL(r)
+	case TypeHashListpack:
+		return DecodeHashListpack(r)
+	case TypeHashListpackEx:
+		return DecodeHashListpackWithTTL(r)
+	default:
+		return nil, ErrUnsupportedType
+	}
+}
+
+// DumpDB writes every key in db to path in RDB format, covering the string and hash types this
+// codec supports (list/set/zset are not wired up yet). A key whose value isn't one of those
+// types aborts the whole snapshot rather than being silently skipped, since a dump missing keys
+// with no indication of which is worse than no dump at all; the partial file is removed so a
+// failed DumpDB never leaves a truncated dump.rdb behind for LoadDB to pick up later.
+func DumpDB(db DBIterator, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if _, err := w.WriteString(magicHeader); err != nil {
+		return abortDump(path, err)
+	}
+	if err := writeByte(w, opSelectDB); err != nil {
+		return abortDump(path, err)
+	}
+	if err := writeLength(w, 0); err != nil {
+		return abortDump(path, err)
+	}
+
+	var encodeErr error
+	db.ForEach(func(key string, entity *database.DataEntity, expiration *time.Time) bool {
+		if expiration != nil {
+			if err := writeByte(w, opExpireMs); err == nil {
+				_ = binary.Write(w, binary.BigEndian, expiration.UnixMilli())
+			}
+		}
+		if err := encodeEntity(w, key, entity.Data); err != nil {
+			encodeErr = fmt.Errorf("rdb: key %q: %w", key, err)
+			return false
+		}
+		return true
+	})
+	if encodeErr != nil {
+		return abortDump(path, encodeErr)
+	}
+
+	if err := writeByte(w, opEOF); err != nil {
+		return abortDump(path, err)
+	}
+	// A zero checksum tells a stock Redis loader that checksum verification is disabled.
+	if err := binary.Write(w, binary.LittleEndian, uint64(0)); err != nil {
+		return abortDump(path, err)
+	}
+	if err := w.Flush(); err != nil {
+		return abortDump(path, err)
+	}
+	return nil
+}
+
+// abortDump removes the partially-written file at path, so a failed dump never leaves a
+// truncated snapshot behind, then returns origErr.
+func abortDump(path string, origErr error) error {
+	_ = os.Remove(path)
+	return origErr
+}
+
+// LoadDB reads path and repopulates loader from it. A missing file is not an error, since it
+// simply means no snapshot has been saved yet.
+func LoadDB(loader DBLoader, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header := make([]byte, len(magicHeader))
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+	if string(header) != magicHeader {
+		return ErrBadMagic
+	}
+
+	for {
+		op, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch op {
+		case opEOF:
+			return nil
+		case opSelectDB:
+			if _, err := readLength(r); err != nil {
+				return err
+			}
+		case opExpireMs:
+			// Key-level expiration isn't threaded through DBLoader yet; consume and discard it.
+			var ms int64
+			if err := binary.Read(r, binary.BigEndian, &ms); err != nil {
+				return err
+			}
+		default:
+			key, err := readBytes(r)
+			if err != nil {
+				return err
+			}
+			data, err := decodeValue(r, op)
+			if err != nil {
+				return err
+			}
+			loader.PutEntity(string(key), &database.DataEntity{Data: data})
+		}
+	}
+}
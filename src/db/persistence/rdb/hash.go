@@ -0,0 +1,176 @@
+package rdb
+
+import (
+	"encoding/binary"
+	"io"
+
+	"traitor/db/struct/dict"
+)
+
+// EncodeHash serializes d as RDB_TYPE_HASH: a field count followed by length-prefixed
+// field/value pairs, with no trailer. This is exactly the plain encoding a stock
+// Redis-compatible reader expects; use EncodeHashWithTTL instead for hashes carrying
+// per-field TTLs, which needs a distinct type tag to stay unambiguous.
+func EncodeHash(w io.Writer, d dict.Dict) error {
+	if err := writeLength(w, d.Len()); err != nil {
+		return err
+	}
+	var walkErr error
+	d.ForEach(func(field string, val interface{}) bool {
+		value, _ := val.([]byte)
+		if err := writeBytes(w, []byte(field)); err != nil {
+			walkErr = err
+			return false
+		}
+		if err := writeBytes(w, value); err != nil {
+			walkErr = err
+			return false
+		}
+		return true
+	})
+	return walkErr
+}
+
+// DecodeHash reads back a value written by EncodeHash into a dict.SimpleDict.
+func DecodeHash(r io.Reader) (dict.Dict, error) {
+	n, err := readLength(r)
+	if err != nil {
+		return nil, err
+	}
+	d := dict.MakeSimple()
+	for i := 0; i < n; i++ {
+		field, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		value, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		d.Put(string(field), value)
+	}
+	return d, nil
+}
+
+// EncodeHashWithTTL writes the same payload as EncodeHash, followed by a trailer of any
+// per-field TTLs set by HEXPIRE and friends. It is paired with TypeHashMetadata, a tag no
+// plain-hash reader will ever see, so the trailer can never be mistaken for the start of the
+// next record (and a genuine Redis RDB_TYPE_HASH record, which never has this trailer, is
+// never misread as one).
+func EncodeHashWithTTL(w io.Writer, d dict.Dict) error {
+	if err := EncodeHash(w, d); err != nil {
+		return err
+	}
+	return encodeFieldTTLs(w, d)
+}
+
+// DecodeHashWithTTL reads back a value written by EncodeHashWithTTL.
+func DecodeHashWithTTL(r io.Reader) (dict.Dict, error) {
+	d, err := DecodeHash(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeFieldTTLs(r, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// EncodeHashListpack serializes a dict.ListPack using its compact entries buffer directly, with
+// no trailer, selected when the hash is small enough to still use the listpack encoding and has
+// no per-field TTLs. It is written under Redis's RDB_TYPE_HASH_LISTPACK tag value, but the
+// payload is dict.ListPack's layout (4-byte little-endian field/value lengths), not Redis's
+// listpack encoding; DecodeHashListpack reads only that layout.
+func EncodeHashListpack(w io.Writer, lp *dict.ListPack) error {
+	return writeBytes(w, lp.Bytes())
+}
+
+// DecodeHashListpack reads back a value written by EncodeHashListpack.
+func DecodeHashListpack(r io.Reader) (dict.Dict, error) {
+	raw, err := readBytes(r)
+	if err != nil {
+		return nil, err
+	}
+	return dict.ListPackFromBytes(raw), nil
+}
+
+// EncodeHashListpackWithTTL writes the same payload as EncodeHashListpack, followed by a
+// trailer of any per-field TTLs, paired with the private TypeHashListpackEx tag for the same
+// reason EncodeHashWithTTL uses TypeHashMetadata.
+func EncodeHashListpackWithTTL(w io.Writer, lp *dict.ListPack) error {
+	if err := EncodeHashListpack(w, lp); err != nil {
+		return err
+	}
+	return encodeFieldTTLs(w, lp)
+}
+
+// DecodeHashListpackWithTTL reads back a value written by EncodeHashListpackWithTTL.
+func DecodeHashListpackWithTTL(r io.Reader) (dict.Dict, error) {
+	d, err := DecodeHashListpack(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeFieldTTLs(r, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// HasFieldTTL reports whether any field in d carries a per-field TTL, used to pick between the
+// plain and TTL-bearing encodings above.
+func HasFieldTTL(d dict.Dict) bool {
+	found := false
+	d.ForEach(func(field string, _ interface{}) bool {
+		if _, hasTTL := d.TTL(field); hasTTL {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+func encodeFieldTTLs(w io.Writer, d dict.Dict) error {
+	type fieldTTL struct {
+		field      string
+		expireAtMs int64
+	}
+	var ttls []fieldTTL
+	d.ForEach(func(field string, _ interface{}) bool {
+		if expireAtMs, hasTTL := d.TTL(field); hasTTL {
+			ttls = append(ttls, fieldTTL{field, expireAtMs})
+		}
+		return true
+	})
+	if err := writeLength(w, len(ttls)); err != nil {
+		return err
+	}
+	for _, t := range ttls {
+		if err := writeBytes(w, []byte(t.field)); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.BigEndian, t.expireAtMs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeFieldTTLs(r io.Reader, d dict.Dict) error {
+	n, err := readLength(r)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		field, err := readBytes(r)
+		if err != nil {
+			return err
+		}
+		var expireAtMs int64
+		if err := binary.Read(r, binary.BigEndian, &expireAtMs); err != nil {
+			return err
+		}
+		d.ExpireField(string(field), expireAtMs)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package dict
+
+// Consumer is used to traverse a Dict, it receives key and value as params, return true to continue or false to break
+type Consumer func(key string, val interface{}) bool
+
+// Dict is interface of a key-value data structure
+type Dict interface {
+	Get(key string) (val interface{}, exists bool)
+	Len() int
+	Put(key string, val interface{}) (result int)
+	PutIfAbsent(key string, val interface{}) (result int)
+	PutIfExists(key string, val interface{}) (result int)
+	Remove(key string) (result int)
+	ForEach(consumer Consumer)
+	Keys() []string
+	RandomKeys(limit int) []string
+	RandomDistinctKeys(limit int) []string
+	Clear()
+	// DictScan walks the dict cursor by cursor, returning at least count keys (approximate)
+	// matching pattern, along with the cursor to resume from. A returned cursor of 0 means
+	// iteration has completed a full cycle.
+	DictScan(cursor int, count int, pattern string) (keys [][]byte, nextCursor int)
+	// ExpireField binds key's expiration time, as a unix timestamp in milliseconds, to field.
+	// An expireAtMs <= 0 clears any existing expiration instead.
+	ExpireField(key string, expireAtMs int64)
+	// TTL returns the unix-ms expiration time bound to key and whether one is set.
+	TTL(key string) (expireAtMs int64, exists bool)
+	// PersistField clears key's expiration time, returning true if one was set.
+	PersistField(key string) bool
+}
@@ -0,0 +1,283 @@
+package dict
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"sort"
+
+	"traitor/db/lib/wildcard"
+)
+
+// ListPack is a compact dict.Dict implementation for small hashes. Entries are stored
+// length-prefixed, one after another, in a single contiguous byte buffer and kept in
+// insertion order, mirroring Redis's listpack encoding. Lookups and iteration are O(n)
+// scans over the buffer; callers are expected to promote to SimpleDict once the hash
+// grows past the configured listpack thresholds.
+type ListPack struct {
+	buf   []byte
+	count int
+	ttl   fieldTTLs
+}
+
+// MakeListPack creates an empty ListPack
+func MakeListPack() *ListPack {
+	return &ListPack{}
+}
+
+// lpEntry describes the location of a field/value pair inside the buffer
+type lpEntry struct {
+	offset, end          int
+	fieldStart, fieldEnd int
+	valueStart, valueEnd int
+}
+
+func (lp *ListPack) walk(fn func(e lpEntry) bool) {
+	offset := 0
+	for offset < len(lp.buf) {
+		fieldLen := int(binary.LittleEndian.Uint32(lp.buf[offset : offset+4]))
+		valueLen := int(binary.LittleEndian.Uint32(lp.buf[offset+4 : offset+8]))
+		fieldStart := offset + 8
+		fieldEnd := fieldStart + fieldLen
+		valueEnd := fieldEnd + valueLen
+		e := lpEntry{
+			offset: offset, end: valueEnd,
+			fieldStart: fieldStart, fieldEnd: fieldEnd,
+			valueStart: fieldEnd, valueEnd: valueEnd,
+		}
+		if !fn(e) {
+			return
+		}
+		offset = valueEnd
+	}
+}
+
+func (lp *ListPack) find(key string) (e lpEntry, found bool) {
+	lp.walk(func(cur lpEntry) bool {
+		if string(lp.buf[cur.fieldStart:cur.fieldEnd]) == key {
+			e = cur
+			found = true
+			return false
+		}
+		return true
+	})
+	return
+}
+
+func toBytes(val interface{}) []byte {
+	switch v := val.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	default:
+		return nil
+	}
+}
+
+func encodeEntry(field, value []byte) []byte {
+	entry := make([]byte, 8+len(field)+len(value))
+	binary.LittleEndian.PutUint32(entry[0:4], uint32(len(field)))
+	binary.LittleEndian.PutUint32(entry[4:8], uint32(len(value)))
+	copy(entry[8:8+len(field)], field)
+	copy(entry[8+len(field):], value)
+	return entry
+}
+
+// Get returns the value bound to key in O(n) time
+func (lp *ListPack) Get(key string) (val interface{}, exists bool) {
+	e, found := lp.find(key)
+	if !found {
+		return nil, false
+	}
+	value := make([]byte, e.valueEnd-e.valueStart)
+	copy(value, lp.buf[e.valueStart:e.valueEnd])
+	return value, true
+}
+
+// Len returns the number of fields stored
+func (lp *ListPack) Len() int {
+	return lp.count
+}
+
+// Put appends a new entry or replaces an existing one in place, returning 1 if the field
+// was newly created, 0 if it already existed
+func (lp *ListPack) Put(key string, val interface{}) (result int) {
+	value := toBytes(val)
+	field := []byte(key)
+	e, found := lp.find(key)
+	if !found {
+		lp.buf = append(lp.buf, encodeEntry(field, value)...)
+		lp.count++
+		return 1
+	}
+	newEntry := encodeEntry(field, value)
+	rest := make([]byte, len(lp.buf)-e.end)
+	copy(rest, lp.buf[e.end:])
+	lp.buf = append(lp.buf[:e.offset], append(newEntry, rest...)...)
+	return 0
+}
+
+// PutIfAbsent puts the value only if the field does not already exist
+func (lp *ListPack) PutIfAbsent(key string, val interface{}) (result int) {
+	if _, found := lp.find(key); found {
+		return 0
+	}
+	lp.buf = append(lp.buf, encodeEntry([]byte(key), toBytes(val))...)
+	lp.count++
+	return 1
+}
+
+// PutIfExists puts the value only if the field already exists
+func (lp *ListPack) PutIfExists(key string, val interface{}) (result int) {
+	e, found := lp.find(key)
+	if !found {
+		return 0
+	}
+	newEntry := encodeEntry([]byte(key), toBytes(val))
+	rest := make([]byte, len(lp.buf)-e.end)
+	copy(rest, lp.buf[e.end:])
+	lp.buf = append(lp.buf[:e.offset], append(newEntry, rest...)...)
+	return 1
+}
+
+// Remove deletes the field, returning 1 if it was present
+func (lp *ListPack) Remove(key string) (result int) {
+	e, found := lp.find(key)
+	if !found {
+		return 0
+	}
+	lp.buf = append(lp.buf[:e.offset], lp.buf[e.end:]...)
+	lp.count--
+	lp.ttl.remove(key)
+	return 1
+}
+
+// ForEach traverses fields in insertion order, preserving HGETALL semantics
+func (lp *ListPack) ForEach(consumer Consumer) {
+	lp.walk(func(e lpEntry) bool {
+		value := make([]byte, e.valueEnd-e.valueStart)
+		copy(value, lp.buf[e.valueStart:e.valueEnd])
+		return consumer(string(lp.buf[e.fieldStart:e.fieldEnd]), value)
+	})
+}
+
+// Keys returns all field names in insertion order
+func (lp *ListPack) Keys() []string {
+	result := make([]string, 0, lp.count)
+	lp.walk(func(e lpEntry) bool {
+		result = append(result, string(lp.buf[e.fieldStart:e.fieldEnd]))
+		return true
+	})
+	return result
+}
+
+// RandomKeys randomly returns keys of the given number, may contain duplicated key. Unlike
+// SimpleDict, which gets randomness for free from Go's randomized map iteration, Keys() here
+// comes back in fixed insertion order, so each pick is drawn independently at random instead.
+func (lp *ListPack) RandomKeys(limit int) []string {
+	keys := lp.Keys()
+	if len(keys) == 0 {
+		return nil
+	}
+	result := make([]string, limit)
+	for i := 0; i < limit; i++ {
+		result[i] = keys[rand.Intn(len(keys))]
+	}
+	return result
+}
+
+// RandomDistinctKeys randomly returns keys of the given number, won't contain duplicated key
+func (lp *ListPack) RandomDistinctKeys(limit int) []string {
+	keys := lp.Keys()
+	if limit > len(keys) {
+		limit = len(keys)
+	}
+	rand.Shuffle(len(keys), func(i, j int) {
+		keys[i], keys[j] = keys[j], keys[i]
+	})
+	return keys[:limit]
+}
+
+// Clear removes all fields
+func (lp *ListPack) Clear() {
+	lp.buf = nil
+	lp.count = 0
+	lp.ttl.clear()
+}
+
+// ExpireField binds an expiration time, as a unix timestamp in milliseconds, to key
+func (lp *ListPack) ExpireField(key string, expireAtMs int64) {
+	lp.ttl.expire(key, expireAtMs)
+}
+
+// TTL returns the unix-ms expiration time bound to key and whether one is set
+func (lp *ListPack) TTL(key string) (expireAtMs int64, exists bool) {
+	return lp.ttl.ttl(key)
+}
+
+// PersistField clears key's expiration time, returning true if one was set
+func (lp *ListPack) PersistField(key string) bool {
+	return lp.ttl.persist(key)
+}
+
+// Bytes returns a copy of the listpack's raw, length-prefixed entry buffer, for persisting the
+// compact encoding as-is rather than re-encoding field by field.
+func (lp *ListPack) Bytes() []byte {
+	buf := make([]byte, len(lp.buf))
+	copy(buf, lp.buf)
+	return buf
+}
+
+// ListPackFromBytes reconstructs a ListPack from a buffer previously returned by Bytes.
+func ListPackFromBytes(buf []byte) *ListPack {
+	lp := &ListPack{buf: make([]byte, len(buf))}
+	copy(lp.buf, buf)
+	lp.walk(func(lpEntry) bool {
+		lp.count++
+		return true
+	})
+	return lp
+}
+
+// DictScan walks the field names in sorted order, as SimpleDict.DictScan does, so cursor is an
+// offset into that order; field names are matched against pattern and nextCursor is 0 once the
+// scan has completed a full pass.
+func (lp *ListPack) DictScan(cursor int, count int, pattern string) ([][]byte, int) {
+	keys := lp.Keys()
+	sort.Strings(keys)
+	if cursor < 0 || cursor >= len(keys) {
+		return nil, 0
+	}
+	matcher, err := wildcard.CompilePattern(pattern)
+	if err != nil {
+		return nil, 0
+	}
+	result := make([][]byte, 0, count)
+	i := cursor
+	for ; i < len(keys) && len(result) < count; i++ {
+		if matcher.IsMatch(keys[i]) {
+			result = append(result, []byte(keys[i]))
+		}
+	}
+	nextCursor := i
+	if nextCursor >= len(keys) {
+		nextCursor = 0
+	}
+	return result, nextCursor
+}
+
+// MaxEntrySize returns the length in bytes of the largest field or value currently stored,
+// used by callers to decide whether the listpack encoding should be promoted to a hashmap
+func (lp *ListPack) MaxEntrySize() int {
+	max := 0
+	lp.walk(func(e lpEntry) bool {
+		if fieldLen := e.fieldEnd - e.fieldStart; fieldLen > max {
+			max = fieldLen
+		}
+		if valueLen := e.valueEnd - e.valueStart; valueLen > max {
+			max = valueLen
+		}
+		return true
+	})
+	return max
+}
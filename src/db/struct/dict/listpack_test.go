@@ -0,0 +1,185 @@
+package dict
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListPackPutGet(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		value string
+	}{
+		{"short field and value", "f", "v"},
+		{"empty value", "field", ""},
+		{"longer value", "field", "a fairly long value to exercise buffer growth"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lp := MakeListPack()
+			if result := lp.Put(tt.field, []byte(tt.value)); result != 1 {
+				t.Fatalf("Put on new field = %d, want 1", result)
+			}
+			val, exists := lp.Get(tt.field)
+			if !exists {
+				t.Fatalf("Get(%q) exists = false, want true", tt.field)
+			}
+			if !reflect.DeepEqual(val, []byte(tt.value)) {
+				t.Fatalf("Get(%q) = %q, want %q", tt.field, val, tt.value)
+			}
+			if lp.Len() != 1 {
+				t.Fatalf("Len() = %d, want 1", lp.Len())
+			}
+		})
+	}
+}
+
+func TestListPackPutReplacesExisting(t *testing.T) {
+	lp := MakeListPack()
+	lp.Put("f", []byte("old"))
+	if result := lp.Put("f", []byte("new")); result != 0 {
+		t.Fatalf("Put on existing field = %d, want 0", result)
+	}
+	if lp.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1 after overwrite", lp.Len())
+	}
+	val, _ := lp.Get("f")
+	if !reflect.DeepEqual(val, []byte("new")) {
+		t.Fatalf("Get(\"f\") = %q, want %q", val, "new")
+	}
+}
+
+func TestListPackRemove(t *testing.T) {
+	lp := MakeListPack()
+	lp.Put("a", []byte("1"))
+	lp.Put("b", []byte("2"))
+	lp.Put("c", []byte("3"))
+
+	if result := lp.Remove("b"); result != 1 {
+		t.Fatalf("Remove(\"b\") = %d, want 1", result)
+	}
+	if result := lp.Remove("b"); result != 0 {
+		t.Fatalf("Remove(\"b\") again = %d, want 0", result)
+	}
+	if lp.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", lp.Len())
+	}
+	if _, exists := lp.Get("b"); exists {
+		t.Fatalf("Get(\"b\") exists = true after Remove, want false")
+	}
+	// a and c should still be reachable after splicing b out of the buffer
+	if val, exists := lp.Get("a"); !exists || !reflect.DeepEqual(val, []byte("1")) {
+		t.Fatalf("Get(\"a\") = %q, %v, want \"1\", true", val, exists)
+	}
+	if val, exists := lp.Get("c"); !exists || !reflect.DeepEqual(val, []byte("3")) {
+		t.Fatalf("Get(\"c\") = %q, %v, want \"3\", true", val, exists)
+	}
+}
+
+func TestListPackForEachPreservesInsertionOrder(t *testing.T) {
+	lp := MakeListPack()
+	order := []string{"z", "a", "m", "b"}
+	for _, field := range order {
+		lp.Put(field, []byte(field))
+	}
+
+	var seen []string
+	lp.ForEach(func(field string, _ interface{}) bool {
+		seen = append(seen, field)
+		return true
+	})
+	if !reflect.DeepEqual(seen, order) {
+		t.Fatalf("ForEach order = %v, want %v", seen, order)
+	}
+	if !reflect.DeepEqual(lp.Keys(), order) {
+		t.Fatalf("Keys() = %v, want %v", lp.Keys(), order)
+	}
+}
+
+func TestListPackMaxEntrySize(t *testing.T) {
+	lp := MakeListPack()
+	if got := lp.MaxEntrySize(); got != 0 {
+		t.Fatalf("MaxEntrySize() on empty listpack = %d, want 0", got)
+	}
+	lp.Put("short", []byte("v"))
+	lp.Put("f", []byte("a much longer value than the field name"))
+	if got, want := lp.MaxEntrySize(), len("a much longer value than the field name"); got != want {
+		t.Fatalf("MaxEntrySize() = %d, want %d", got, want)
+	}
+}
+
+func TestListPackRandomKeysVaries(t *testing.T) {
+	lp := MakeListPack()
+	fields := []string{"a", "b", "c", "d", "e"}
+	for _, field := range fields {
+		lp.Put(field, []byte(field))
+	}
+
+	seenDifferent := false
+	first := lp.RandomKeys(3)
+	for attempt := 0; attempt < 50; attempt++ {
+		if !reflect.DeepEqual(lp.RandomKeys(3), first) {
+			seenDifferent = true
+			break
+		}
+	}
+	if !seenDifferent {
+		t.Fatalf("RandomKeys(3) returned %v on every one of 50 calls, want some variation", first)
+	}
+}
+
+func TestListPackRandomKeysLength(t *testing.T) {
+	lp := MakeListPack()
+	lp.Put("a", []byte("1"))
+	lp.Put("b", []byte("2"))
+
+	if got := lp.RandomKeys(4); len(got) != 4 {
+		t.Fatalf("RandomKeys(4) len = %d, want 4", len(got))
+	}
+	if got := lp.RandomKeys(0); len(got) != 0 {
+		t.Fatalf("RandomKeys(0) len = %d, want 0", len(got))
+	}
+}
+
+func TestListPackRandomDistinctKeys(t *testing.T) {
+	lp := MakeListPack()
+	fields := []string{"a", "b", "c", "d", "e"}
+	for _, field := range fields {
+		lp.Put(field, []byte(field))
+	}
+
+	got := lp.RandomDistinctKeys(3)
+	if len(got) != 3 {
+		t.Fatalf("RandomDistinctKeys(3) len = %d, want 3", len(got))
+	}
+	seen := make(map[string]bool, len(got))
+	for _, k := range got {
+		if seen[k] {
+			t.Fatalf("RandomDistinctKeys(3) = %v, contains duplicate %q", got, k)
+		}
+		seen[k] = true
+	}
+
+	if got := lp.RandomDistinctKeys(10); len(got) != len(fields) {
+		t.Fatalf("RandomDistinctKeys(10) on a %d-field listpack len = %d, want %d", len(fields), len(got), len(fields))
+	}
+}
+
+func TestListPackBytesRoundTrip(t *testing.T) {
+	lp := MakeListPack()
+	lp.Put("a", []byte("1"))
+	lp.Put("b", []byte("2"))
+
+	round := ListPackFromBytes(lp.Bytes())
+	if round.Len() != lp.Len() {
+		t.Fatalf("ListPackFromBytes Len() = %d, want %d", round.Len(), lp.Len())
+	}
+	if !reflect.DeepEqual(round.Keys(), lp.Keys()) {
+		t.Fatalf("ListPackFromBytes Keys() = %v, want %v", round.Keys(), lp.Keys())
+	}
+	val, exists := round.Get("b")
+	if !exists || !reflect.DeepEqual(val, []byte("2")) {
+		t.Fatalf("ListPackFromBytes Get(\"b\") = %q, %v, want \"2\", true", val, exists)
+	}
+}
@@ -0,0 +1,175 @@
+package dict
+
+import (
+	"sort"
+
+	"traitor/db/lib/wildcard"
+)
+
+// SimpleDict wraps a builtin map, it is not thread safe
+type SimpleDict struct {
+	m   map[string]interface{}
+	ttl fieldTTLs
+}
+
+// MakeSimple creates a new SimpleDict
+func MakeSimple() *SimpleDict {
+	return &SimpleDict{
+		m: make(map[string]interface{}),
+	}
+}
+
+// Get returns the binding value and whether the key is exist
+func (d *SimpleDict) Get(key string) (val interface{}, exists bool) {
+	val, ok := d.m[key]
+	return val, ok
+}
+
+// Len returns the number of dict
+func (d *SimpleDict) Len() int {
+	if d.m == nil {
+		panic("m is nil")
+	}
+	return len(d.m)
+}
+
+// Put puts key value into dict and returns the number of new inserted key-value
+func (d *SimpleDict) Put(key string, val interface{}) (result int) {
+	_, existed := d.m[key]
+	d.m[key] = val
+	if existed {
+		return 0
+	}
+	return 1
+}
+
+// PutIfAbsent puts value if the key is not exists and returns the number of updated key-value
+func (d *SimpleDict) PutIfAbsent(key string, val interface{}) (result int) {
+	_, existed := d.m[key]
+	if existed {
+		return 0
+	}
+	d.m[key] = val
+	return 1
+}
+
+// PutIfExists puts value if the key is exists and returns the number of inserted key-value
+func (d *SimpleDict) PutIfExists(key string, val interface{}) (result int) {
+	_, existed := d.m[key]
+	if existed {
+		d.m[key] = val
+		return 1
+	}
+	return 0
+}
+
+// Remove removes the key and return the number of deleted key-value
+func (d *SimpleDict) Remove(key string) (result int) {
+	_, existed := d.m[key]
+	delete(d.m, key)
+	d.ttl.remove(key)
+	if existed {
+		return 1
+	}
+	return 0
+}
+
+// ForEach traversal the dict
+func (d *SimpleDict) ForEach(consumer Consumer) {
+	for k, v := range d.m {
+		if !consumer(k, v) {
+			break
+		}
+	}
+}
+
+// Keys returns all keys in dict
+func (d *SimpleDict) Keys() []string {
+	result := make([]string, len(d.m))
+	i := 0
+	for k := range d.m {
+		result[i] = k
+		i++
+	}
+	return result
+}
+
+// RandomKeys randomly returns keys of the given number, may contain duplicated key
+func (d *SimpleDict) RandomKeys(limit int) []string {
+	result := make([]string, limit)
+	for i := 0; i < limit; i++ {
+		for k := range d.m {
+			result[i] = k
+			break
+		}
+	}
+	return result
+}
+
+// RandomDistinctKeys randomly returns keys of the given number, won't contain duplicated key
+func (d *SimpleDict) RandomDistinctKeys(limit int) []string {
+	size := limit
+	if size > len(d.m) {
+		size = len(d.m)
+	}
+	result := make([]string, size)
+	i := 0
+	for k := range d.m {
+		if i == size {
+			break
+		}
+		result[i] = k
+		i++
+	}
+	return result
+}
+
+// Clear removes all keys in dict
+func (d *SimpleDict) Clear() {
+	*d = *MakeSimple()
+}
+
+// ExpireField binds an expiration time, as a unix timestamp in milliseconds, to key
+func (d *SimpleDict) ExpireField(key string, expireAtMs int64) {
+	d.ttl.expire(key, expireAtMs)
+}
+
+// TTL returns the unix-ms expiration time bound to key and whether one is set
+func (d *SimpleDict) TTL(key string) (expireAtMs int64, exists bool) {
+	return d.ttl.ttl(key)
+}
+
+// PersistField clears key's expiration time, returning true if one was set
+func (d *SimpleDict) PersistField(key string) bool {
+	return d.ttl.persist(key)
+}
+
+// DictScan walks the dict key-by-key in a stable sorted order, treating that order as the
+// "bucket" sequence. cursor is the offset to resume from; nextCursor is 0 once the scan has
+// completed a full pass. Field names are filtered against pattern before being returned.
+func (d *SimpleDict) DictScan(cursor int, count int, pattern string) ([][]byte, int) {
+	if cursor < 0 || cursor >= len(d.m) {
+		return nil, 0
+	}
+	keys := d.Keys()
+	sort.Strings(keys)
+
+	matcher, err := wildcard.CompilePattern(pattern)
+	if err != nil {
+		return nil, 0
+	}
+
+	result := make([][]byte, 0, count)
+	i := cursor
+	for ; i < len(keys) && len(result) < count; i++ {
+		key := keys[i]
+		if matcher.IsMatch(key) {
+			result = append(result, []byte(key))
+		}
+	}
+	nextCursor := i
+	if nextCursor >= len(keys) {
+		nextCursor = 0
+	}
+	return result, nextCursor
+}
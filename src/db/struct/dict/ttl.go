@@ -0,0 +1,48 @@
+package dict
+
+// fieldTTLs is an optional side-map from field name to expiry unix-ms, embedded by Dict
+// implementations to support per-field expiration (HEXPIRE and friends) without requiring
+// every dict backend to know about TTLs internally.
+type fieldTTLs struct {
+	m map[string]int64
+}
+
+func (t *fieldTTLs) expire(key string, expireAtMs int64) {
+	if expireAtMs <= 0 {
+		t.persist(key)
+		return
+	}
+	if t.m == nil {
+		t.m = make(map[string]int64)
+	}
+	t.m[key] = expireAtMs
+}
+
+func (t *fieldTTLs) ttl(key string) (expireAtMs int64, exists bool) {
+	if t.m == nil {
+		return 0, false
+	}
+	expireAtMs, exists = t.m[key]
+	return
+}
+
+func (t *fieldTTLs) persist(key string) bool {
+	if t.m == nil {
+		return false
+	}
+	if _, exists := t.m[key]; !exists {
+		return false
+	}
+	delete(t.m, key)
+	return true
+}
+
+func (t *fieldTTLs) remove(key string) {
+	if t.m != nil {
+		delete(t.m, key)
+	}
+}
+
+func (t *fieldTTLs) clear() {
+	t.m = nil
+}